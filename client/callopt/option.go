@@ -0,0 +1,62 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package callopt defines per-call options: overrides that apply to a
+// single RPC instead of every call a client makes.
+package callopt
+
+import (
+	"github.com/cloudwego/kitex/internal/client"
+	"github.com/cloudwego/kitex/pkg/retry"
+)
+
+// Option is the only way to configure a single call.
+type Option struct {
+	f func(*client.Options)
+}
+
+// Apply runs every opt against o, in order.
+func Apply(o *client.Options, opts []Option) {
+	for _, opt := range opts {
+		opt.f(o)
+	}
+}
+
+// WithHedgedRequest overrides the client's hedged request policy for this
+// call only. Passing nil disables hedging for this call even if the client
+// was built with WithHedgedRequest.
+func WithHedgedRequest(p *retry.HedgingPolicy) Option {
+	return Option{f: func(o *client.Options) {
+		// o.RetryPolicy may be the same *retry.Policy the base client uses
+		// for every other call; clone it before mutating so this call-scoped
+		// override can't leak into the client-wide policy.
+		policy := retry.Policy{}
+		if o.RetryPolicy != nil {
+			policy = *o.RetryPolicy
+		}
+		policy.HedgingPolicy = p
+		if p != nil {
+			// Hedging is exclusive with failure retry and backup request.
+			policy.FailurePolicy = nil
+			policy.BackupPolicy = nil
+			policy.Enable = true
+			policy.Type = retry.HedgingType
+		} else if policy.Type == retry.HedgingType {
+			policy.Enable = false
+		}
+		o.RetryPolicy = &policy
+	}}
+}
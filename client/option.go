@@ -18,15 +18,21 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strings"
 	"time"
 
+	"github.com/quic-go/quic-go"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/cloudwego/kitex/internal/client"
 	internal_stats "github.com/cloudwego/kitex/internal/stats"
 	"github.com/cloudwego/kitex/pkg/connpool"
 	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/discovery/consul"
+	"github.com/cloudwego/kitex/pkg/discovery/etcd"
 	"github.com/cloudwego/kitex/pkg/endpoint"
 	"github.com/cloudwego/kitex/pkg/http"
 	"github.com/cloudwego/kitex/pkg/klog"
@@ -35,9 +41,11 @@ import (
 	"github.com/cloudwego/kitex/pkg/remote"
 	"github.com/cloudwego/kitex/pkg/remote/trans/netpollmux"
 	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nquic"
 	"github.com/cloudwego/kitex/pkg/retry"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"github.com/cloudwego/kitex/pkg/stats"
+	"github.com/cloudwego/kitex/pkg/stats/otel"
 	"github.com/cloudwego/kitex/pkg/utils"
 	"github.com/cloudwego/kitex/transport"
 )
@@ -65,6 +73,13 @@ func WithTransportProtocol(tp transport.Protocol) Option {
 			o.RemoteOpt.ConnPool = nphttp2.NewConnPool()
 			o.RemoteOpt.CliHandlerFactory = nphttp2.NewCliTransHandlerFactory()
 		}
+		if tp == transport.QUIC {
+			if o.RemoteOpt.QUICOption == nil {
+				o.RemoteOpt.QUICOption = nquic.NewClientOption()
+			}
+			o.RemoteOpt.ConnPool = nquic.NewConnPool(o.RemoteOpt.QUICOption)
+			o.RemoteOpt.CliHandlerFactory = nquic.NewCliTransHandlerFactory(o.RemoteOpt.QUICOption)
+		}
 		di.Push(fmt.Sprintf("WithTransportProtocol(%s)", tpName))
 		rpcinfo.AsMutableRPCConfig(o.Configs).SetTransportProtocol(tp)
 	}}
@@ -171,6 +186,38 @@ func WithResolver(r discovery.Resolver) Option {
 	}}
 }
 
+// WithEtcdResolver provides an etcd v3 backed Resolver for kitex client, so
+// instances can be discovered from an etcd cluster instead of DNS or a fixed
+// host list. It panics if the etcd client cannot be constructed (e.g. bad
+// TLS config); this mirrors WithHostPorts panicking on bad addresses, since
+// both are caller configuration errors that should fail fast at startup.
+func WithEtcdResolver(cfg etcd.Config, opts ...etcd.Option) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithEtcdResolver(%+v)", cfg))
+
+		r, err := etcd.NewEtcdResolver(cfg, opts...)
+		if err != nil {
+			panic(fmt.Errorf("WithEtcdResolver: %w", err))
+		}
+		o.Resolver = r
+	}}
+}
+
+// WithConsulResolver provides a Consul catalog backed Resolver for kitex
+// client. Use consul.WithTagFilter and consul.WithHealthyOnly to restrict
+// results server-side instead of filtering client-side after the fact.
+func WithConsulResolver(addr string, opts ...consul.Option) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithConsulResolver(%s)", addr))
+
+		r, err := consul.NewConsulResolver(addr, opts...)
+		if err != nil {
+			panic(fmt.Errorf("WithConsulResolver: %w", err))
+		}
+		o.Resolver = r
+	}}
+}
+
 // WithHTTPResolver specifies resolver for url (which specified by WithURL).
 func WithHTTPResolver(r http.Resolver) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
@@ -204,6 +251,26 @@ func WithMuxConnection(connNum int) Option {
 	}}
 }
 
+// WithQUICConfig tunes the QUIC transport's idle timeout, keep-alive and max
+// concurrent streams, and sets the TLS config QUIC dials with (QUIC always
+// runs over TLS 1.3). It only takes effect when used with
+// WithTransportProtocol(transport.QUIC), and can be given before or after it.
+func WithQUICConfig(cfg *quic.Config, tlsCfg *tls.Config) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push("WithQUICConfig")
+
+		if o.RemoteOpt.QUICOption == nil {
+			o.RemoteOpt.QUICOption = nquic.NewClientOption()
+		}
+		if cfg != nil {
+			o.RemoteOpt.QUICOption.QUICConfig = cfg
+		}
+		if tlsCfg != nil {
+			o.RemoteOpt.QUICOption.TLSConfig = tlsCfg
+		}
+	}}
+}
+
 // WithLogger sets the Logger for kitex client.
 func WithLogger(logger klog.FormatLogger) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
@@ -227,7 +294,7 @@ func WithLoadBalancer(lb loadbalance.Loadbalancer, opts ...*lbcache.Options) Opt
 // WithRPCTimeout specifies the RPC timeout.
 func WithRPCTimeout(d time.Duration) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
-		di.Push(fmt.Sprintf("WithRPCTimeout(%dms)", d.Milliseconds()))
+		di.Push(fmt.Sprintf("WithRPCTimeout(%.3fms)", internal_stats.DurationMS(d)))
 
 		rpcinfo.AsMutableRPCConfig(o.Configs).SetRPCTimeout(d)
 		o.Locks.Bits |= rpcinfo.BitRPCTimeout
@@ -237,7 +304,7 @@ func WithRPCTimeout(d time.Duration) Option {
 // WithConnectTimeout specifies the connection timeout.
 func WithConnectTimeout(d time.Duration) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
-		di.Push(fmt.Sprintf("WithConnectTimeout(%dms)", d.Milliseconds()))
+		di.Push(fmt.Sprintf("WithConnectTimeout(%.3fms)", internal_stats.DurationMS(d)))
 
 		rpcinfo.AsMutableRPCConfig(o.Configs).SetConnectTimeout(d)
 		o.Locks.Bits |= rpcinfo.BitConnectTimeout
@@ -266,6 +333,15 @@ func WithTracer(c stats.Tracer) Option {
 	}}
 }
 
+// WithOpenTelemetryTracer adds a stats.Tracer backed by tp that reports RPCs
+// as OpenTelemetry client spans and injects W3C traceparent/tracestate (and
+// optionally B3, via otel.WithPropagator) into the outgoing call, so a
+// server behind Envoy/Istio sees a continuous trace. This is additive to
+// WithTracer: both can be registered and will both fire.
+func WithOpenTelemetryTracer(tp trace.TracerProvider, opts ...otel.Option) Option {
+	return WithTracer(otel.NewClientTracer(tp, opts...))
+}
+
 // WithStatsLevel sets the stats level for client.
 func WithStatsLevel(level stats.Level) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
@@ -275,6 +351,26 @@ func WithStatsLevel(level stats.Level) Option {
 	}}
 }
 
+// WithMetricsBucket registers kitex's built-in latency histogram, bucketed
+// into buckets (in milliseconds). Use this to add sub-millisecond boundaries
+// (e.g. 0.1, 0.25, 0.5) for services where most calls stay within the same
+// datacenter and would otherwise collapse into a single "0ms" bucket. The
+// histogram records every RPC's latency as a float64 ms duration, so fast
+// in-datacenter calls keep their percentile fidelity. Call
+// o.TracerCtl.Histogram.Snapshot() (e.g. from a custom reporter registered
+// via WithTracer) to read it out.
+func WithMetricsBucket(buckets []float64) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		di.Push(fmt.Sprintf("WithMetricsBucket(%+v)", buckets))
+
+		if o.TracerCtl == nil {
+			o.TracerCtl = &internal_stats.Controller{}
+		}
+		o.TracerCtl.Histogram = stats.NewHistogram(buckets)
+		o.TracerCtl.Append(stats.NewHistogramTracer(o.TracerCtl.Histogram))
+	}}
+}
+
 // WithCodec to set a codec that handle other protocols which not support by kitex
 func WithCodec(c remote.Codec) Option {
 	return Option{F: func(o *client.Options, di *utils.Slice) {
@@ -315,6 +411,9 @@ func WithFailureRetry(p *retry.FailurePolicy) Option {
 		if o.RetryPolicy.BackupPolicy != nil {
 			panic("BackupPolicy has been setup, cannot support Failure Retry at same time")
 		}
+		if o.RetryPolicy.HedgingPolicy != nil {
+			panic("HedgingPolicy has been setup, cannot support Failure Retry at same time")
+		}
 		o.RetryPolicy.FailurePolicy = p
 		o.RetryPolicy.Enable = true
 		o.RetryPolicy.Type = retry.FailureType
@@ -334,8 +433,39 @@ func WithBackupRequest(p *retry.BackupPolicy) Option {
 		if o.RetryPolicy.FailurePolicy != nil {
 			panic("Failure Retry has been setup, cannot support Backup Request at same time")
 		}
+		if o.RetryPolicy.HedgingPolicy != nil {
+			panic("HedgingPolicy has been setup, cannot support Backup Request at same time")
+		}
 		o.RetryPolicy.BackupPolicy = p
 		o.RetryPolicy.Enable = true
 		o.RetryPolicy.Type = retry.BackupType
 	}}
 }
+
+// WithHedgedRequest sets the hedged request policy for client: up to
+// p.MaxAttempts parallel attempts staggered by a delay adapted from observed
+// latency, racing until one returns a non-retryable result. Mutually
+// exclusive with WithFailureRetry and WithBackupRequest, same as those two
+// are with each other.
+func WithHedgedRequest(p *retry.HedgingPolicy) Option {
+	return Option{F: func(o *client.Options, di *utils.Slice) {
+		if p == nil {
+			return
+		}
+		di.Push(fmt.Sprintf("WithHedgedRequest(%+v)", *p))
+		if o.RetryPolicy == nil {
+			o.RetryPolicy = &retry.Policy{}
+		}
+		if o.RetryPolicy.FailurePolicy != nil {
+			panic("Failure Retry has been setup, cannot support Hedged Request at same time")
+		}
+		if o.RetryPolicy.BackupPolicy != nil {
+			panic("BackupPolicy has been setup, cannot support Hedged Request at same time")
+		}
+		o.RetryPolicy.HedgingPolicy = p
+		o.RetryPolicy.Enable = true
+		o.RetryPolicy.Type = retry.HedgingType
+
+		WithInstanceMW(retry.NewHedgingExecutor(p).Middleware).F(o, di)
+	}}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stats holds the tracer controller shared by kitex client and server.
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/stats"
+)
+
+// Controller aggregates every registered stats.Tracer and fans RPC
+// lifecycle events out to each of them.
+type Controller struct {
+	tracers []stats.Tracer
+
+	// Histogram records RPC latency (as float64 milliseconds) when
+	// WithMetricsBucket has configured one; nil until then, since most
+	// clients don't need an in-process histogram on top of whatever
+	// external tracer they registered via WithTracer/WithOpenTelemetryTracer.
+	Histogram *stats.Histogram
+}
+
+// Append registers a tracer with the controller.
+func (c *Controller) Append(col stats.Tracer) {
+	c.tracers = append(c.tracers, col)
+}
+
+// DoStart fires Start on every registered tracer.
+func (c *Controller) DoStart(ctx context.Context) context.Context {
+	for _, t := range c.tracers {
+		ctx = t.Start(ctx)
+	}
+	return ctx
+}
+
+// DoFinish fires Finish on every registered tracer.
+func (c *Controller) DoFinish(ctx context.Context) {
+	for _, t := range c.tracers {
+		t.Finish(ctx)
+	}
+}
+
+// HasTracer reports whether any tracer has been registered, so callers can
+// skip collecting stats entirely when nothing will consume them.
+func (c *Controller) HasTracer() bool {
+	return c != nil && len(c.tracers) > 0
+}
+
+// DurationMS converts d to a float64 number of milliseconds instead of
+// truncating through time.Duration.Milliseconds, so sub-millisecond RPCs
+// still contribute meaningful values to latency histograms and percentiles.
+func DurationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package consul provides a Consul catalog backed discovery.Resolver for
+// kitex, with server-side tag filtering and health-only instance selection.
+package consul
+
+import "time"
+
+// DefaultWaitTime bounds how long a single blocking query is allowed to
+// hang waiting for a change before the resolver reissues it.
+const DefaultWaitTime = 5 * time.Minute
+
+// Option customizes a Consul-backed Resolver.
+type Option struct {
+	apply func(*options)
+}
+
+type options struct {
+	datacenter  string
+	tagFilter   string
+	healthyOnly bool
+	waitTime    time.Duration
+	token       string
+}
+
+// WithDatacenter restricts lookups to a specific Consul datacenter instead of
+// the agent's own.
+func WithDatacenter(dc string) Option {
+	return Option{apply: func(o *options) { o.datacenter = dc }}
+}
+
+// WithTagFilter restricts results server-side using a Consul filter
+// expression evaluated against the service entry, e.g.
+// `Service.Tags contains "canary"`. See Consul's filtering documentation for
+// the expression syntax.
+func WithTagFilter(expr string) Option {
+	return Option{apply: func(o *options) { o.tagFilter = expr }}
+}
+
+// WithHealthyOnly restricts results to instances passing all health checks.
+// When enabled the resolver queries Consul's /health endpoint instead of the
+// plain catalog listing.
+func WithHealthyOnly(healthyOnly bool) Option {
+	return Option{apply: func(o *options) { o.healthyOnly = healthyOnly }}
+}
+
+// WithWaitTime overrides how long each blocking query may hang before being
+// reissued. Defaults to DefaultWaitTime.
+func WithWaitTime(d time.Duration) Option {
+	return Option{apply: func(o *options) { o.waitTime = d }}
+}
+
+// WithToken sets the ACL token used for catalog/health requests.
+func WithToken(token string) Option {
+	return Option{apply: func(o *options) { o.token = token }}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{waitTime: DefaultWaitTime}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return o
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// watchRetryMinDelay and watchRetryMaxDelay bound the backoff the watch loop
+// waits out after a failed lookup before retrying, so a down/unreachable Consul agent doesn't turn
+// into a tight busy-loop burning CPU and spamming logs.
+const (
+	watchRetryMinDelay = 500 * time.Millisecond
+	watchRetryMaxDelay = 30 * time.Second
+)
+
+// resolver is a discovery.Resolver backed by a Consul catalog/health lookup.
+// Like the etcd resolver, the first Resolve for a service starts a
+// background loop that reissues a blocking query (?index=) as soon as the
+// previous one returns, and keeps an in-memory cache current. Resolve reads
+// that cache, and issues a single non-blocking lookup of its own only while
+// the cache for the service is still empty.
+type resolver struct {
+	client *consulapi.Client
+	opts   *options
+
+	mu      sync.RWMutex
+	cache   map[string][]discovery.Instance // serviceName -> instances
+	started map[string]bool                 // serviceName -> watch loop started
+}
+
+// NewConsulResolver builds a discovery.Resolver that looks up services on
+// the Consul agent at addr.
+func NewConsulResolver(addr string, opts ...Option) (discovery.Resolver, error) {
+	o := newOptions(opts)
+
+	cfg := consulapi.DefaultConfig()
+	cfg.Address = addr
+	cfg.Datacenter = o.datacenter
+	cfg.Token = o.token
+
+	cli, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolver{
+		client:  cli,
+		opts:    o,
+		cache:   make(map[string][]discovery.Instance),
+		started: make(map[string]bool),
+	}, nil
+}
+
+// Target implements discovery.Resolver.
+func (r *resolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+// Resolve implements discovery.Resolver. It starts the background
+// blocking-query loop for serviceName on first use and reads the cache the
+// loop maintains. While that cache is still empty it does one lookup of its
+// own without a WaitIndex, so Resolve never waits out Consul's WaitTime
+// (which defaults to several minutes).
+func (r *resolver) Resolve(ctx context.Context, serviceName string) (discovery.Result, error) {
+	r.ensureWatching(serviceName)
+
+	r.mu.RLock()
+	ins := append([]discovery.Instance(nil), r.cache[serviceName]...)
+	r.mu.RUnlock()
+
+	if len(ins) == 0 {
+		var err error
+		ins, _, err = r.lookup(serviceName, (&consulapi.QueryOptions{
+			Datacenter: r.opts.datacenter,
+			Filter:     r.opts.tagFilter,
+		}).WithContext(ctx))
+		if err != nil {
+			return discovery.Result{}, err
+		}
+		r.mu.Lock()
+		r.cache[serviceName] = ins
+		r.mu.Unlock()
+	}
+
+	return discovery.Result{
+		Cacheable: true,
+		CacheKey:  serviceName,
+		Instances: ins,
+	}, nil
+}
+
+// ensureWatching starts the blocking-query loop for serviceName exactly
+// once, the first time Resolve sees it.
+func (r *resolver) ensureWatching(serviceName string) {
+	r.mu.Lock()
+	if r.started[serviceName] {
+		r.mu.Unlock()
+		return
+	}
+	r.started[serviceName] = true
+	r.mu.Unlock()
+
+	go r.watch(serviceName)
+}
+
+// watch repeatedly issues a blocking query for serviceName, reusing the
+// index returned by the previous call so each call returns promptly once
+// something actually changed, and updates the cache under lock after every
+// response. It never returns; it's the background counterpart to the
+// etcd resolver's watch goroutine.
+func (r *resolver) watch(serviceName string) {
+	var lastIndex uint64
+	backoff := watchRetryMinDelay
+	for {
+		q := &consulapi.QueryOptions{
+			WaitIndex:  lastIndex,
+			WaitTime:   r.opts.waitTime,
+			Datacenter: r.opts.datacenter,
+			Filter:     r.opts.tagFilter,
+		}
+
+		ins, meta, err := r.lookup(serviceName, q)
+		if err != nil {
+			klog.Warnf("KITEX: consul resolver watch on %s failed: %v", serviceName, err)
+			time.Sleep(backoff/2 + time.Duration(rand.Int63n(int64(backoff/2+1))))
+			backoff = nextWatchBackoff(backoff)
+			continue
+		}
+		backoff = watchRetryMinDelay
+		lastIndex = meta.LastIndex
+
+		r.mu.Lock()
+		r.cache[serviceName] = ins
+		r.mu.Unlock()
+	}
+}
+
+// nextWatchBackoff doubles cur, capped at watchRetryMaxDelay, for the delay
+// before the watch loop's next retry after a failed lookup.
+func nextWatchBackoff(cur time.Duration) time.Duration {
+	cur *= 2
+	if cur > watchRetryMaxDelay {
+		cur = watchRetryMaxDelay
+	}
+	return cur
+}
+
+func (r *resolver) lookup(serviceName string, q *consulapi.QueryOptions) ([]discovery.Instance, *consulapi.QueryMeta, error) {
+	if r.opts.healthyOnly {
+		entries, meta, err := r.client.Health().Service(serviceName, "", true, q)
+		if err != nil {
+			return nil, nil, err
+		}
+		ins := make([]discovery.Instance, 0, len(entries))
+		for _, e := range entries {
+			ins = append(ins, toInstance(e.Node.Datacenter, e.Service))
+		}
+		return ins, meta, nil
+	}
+
+	entries, meta, err := r.client.Catalog().Service(serviceName, "", q)
+	if err != nil {
+		return nil, nil, err
+	}
+	ins := make([]discovery.Instance, 0, len(entries))
+	for _, e := range entries {
+		ins = append(ins, toInstance(e.Datacenter, &consulapi.AgentService{
+			Address: e.ServiceAddress,
+			Port:    e.ServicePort,
+			Tags:    e.ServiceTags,
+			Meta:    e.ServiceMeta,
+			Weights: e.ServiceWeights,
+		}))
+	}
+	return ins, meta, nil
+}
+
+// toInstance translates a Consul service entry's datacenter, tags and meta
+// into discovery.Instance tags, so existing WithTag matching keeps working
+// regardless of which discovery backend produced the instance.
+func toInstance(datacenter string, svc *consulapi.AgentService) discovery.Instance {
+	tags := make(map[string]string, len(svc.Meta)+len(svc.Tags)+1)
+	for k, v := range svc.Meta {
+		tags[k] = v
+	}
+	for _, t := range svc.Tags {
+		tags[t] = ""
+	}
+	if datacenter != "" {
+		tags["datacenter"] = datacenter
+	}
+
+	weight := svc.Weights.Passing
+	if weight <= 0 {
+		weight = discovery.DefaultWeight
+	}
+
+	addr := fmt.Sprintf("%s:%s", svc.Address, strconv.Itoa(svc.Port))
+	return discovery.NewInstance("tcp", addr, weight, tags)
+}
+
+// Diff implements discovery.Resolver.
+func (r *resolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements discovery.Resolver.
+func (r *resolver) Name() string {
+	return "consul"
+}
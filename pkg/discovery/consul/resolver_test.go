@@ -0,0 +1,60 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"testing"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+func TestToInstance_Address(t *testing.T) {
+	svc := &consulapi.AgentService{
+		Address: "10.0.0.1",
+		Port:    8080,
+		Tags:    []string{"canary"},
+		Meta:    map[string]string{"region": "us-west"},
+		Weights: consulapi.AgentWeights{Passing: 5},
+	}
+
+	ins := toInstance("dc1", svc)
+
+	if got := ins.Address().String(); got != "10.0.0.1:8080" {
+		t.Fatalf("expected address 10.0.0.1:8080, got %s", got)
+	}
+}
+
+func TestNextWatchBackoff_DoublesAndCaps(t *testing.T) {
+	d := watchRetryMinDelay
+	d = nextWatchBackoff(d)
+	if d != 2*watchRetryMinDelay {
+		t.Fatalf("expected backoff to double to %v, got %v", 2*watchRetryMinDelay, d)
+	}
+
+	d = watchRetryMaxDelay
+	d = nextWatchBackoff(d)
+	if d != watchRetryMaxDelay {
+		t.Fatalf("expected backoff to stay capped at %v, got %v", watchRetryMaxDelay, d)
+	}
+
+	d = watchRetryMaxDelay/2 + time.Second
+	d = nextWatchBackoff(d)
+	if d != watchRetryMaxDelay {
+		t.Fatalf("expected backoff to clamp to %v once doubling exceeds it, got %v", watchRetryMaxDelay, d)
+	}
+}
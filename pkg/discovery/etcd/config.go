@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package etcd provides an etcd v3 backed discovery.Resolver and registry.Registry
+// for kitex, so a cluster's instance list can be kept in etcd instead of DNS or a
+// fixed host list.
+package etcd
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+const (
+	// DefaultPrefix is used when Config.Prefix is empty. Instance keys are
+	// stored as DefaultPrefix + "/{serviceName}/{host:port}".
+	DefaultPrefix = "/kitex"
+
+	// DefaultDialTimeout is used when Config.DialTimeout is zero.
+	DefaultDialTimeout = 5 * time.Second
+
+	// DefaultTTL is the lease TTL used when Config.TTL is zero. It bounds how
+	// long a registered instance can look alive after its owner stops
+	// refreshing the lease.
+	DefaultTTL = 10 * time.Second
+)
+
+// Config describes how to connect to an etcd v3 cluster.
+type Config struct {
+	// Endpoints is the list of etcd server addresses, e.g. "127.0.0.1:2379".
+	Endpoints []string
+
+	// Username/Password enable etcd's built-in auth. Leave both empty to
+	// connect without auth.
+	Username string
+	Password string
+
+	// TLSConfig, if set, dials etcd over TLS.
+	TLSConfig *tls.Config
+
+	// DialTimeout bounds the initial connection to the cluster. Defaults to
+	// DefaultDialTimeout.
+	DialTimeout time.Duration
+
+	// Prefix is the key namespace instances are stored/watched under.
+	// Defaults to DefaultPrefix.
+	Prefix string
+
+	// TTL is the lease TTL instances are registered with. Defaults to
+	// DefaultTTL. The registry refreshes the lease at TTL/3 so instances
+	// disappear automatically, without an explicit deregister, at most TTL
+	// after their owner stops.
+	TTL time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = DefaultDialTimeout
+	}
+	if c.Prefix == "" {
+		c.Prefix = DefaultPrefix
+	}
+	if c.TTL <= 0 {
+		c.TTL = DefaultTTL
+	}
+	return c
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// instanceInfo is the JSON payload stored under each instance's etcd key.
+type instanceInfo struct {
+	Weight int               `json:"weight"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+func instanceKey(prefix, serviceName, hostPort string) string {
+	return fmt.Sprintf("%s/%s/%s", prefix, serviceName, hostPort)
+}
+
+func encodeInstance(weight int, tags map[string]string) ([]byte, error) {
+	return json.Marshal(instanceInfo{Weight: weight, Tags: tags})
+}
+
+func decodeInstance(data []byte) (instanceInfo, error) {
+	var info instanceInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return instanceInfo{}, err
+	}
+	return info, nil
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import clientv3 "go.etcd.io/etcd/client/v3"
+
+// Option customizes the requests a resolver issues to etcd. NewEtcdRegistry
+// accepts Options as well but does not use them.
+type Option struct {
+	apply func(*options)
+}
+
+type options struct {
+	etcdOpts []clientv3.OpOption
+}
+
+// WithEtcdOption passes through raw clientv3.OpOption values (e.g. a custom
+// sort order) to the Get call the resolver issues when it lists a service's
+// instances. The Watch it starts afterwards does not use them.
+func WithEtcdOption(opts ...clientv3.OpOption) Option {
+	return Option{apply: func(o *options) {
+		o.etcdOpts = append(o.etcdOpts, opts...)
+	}}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return o
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/registry"
+)
+
+// Registry is a registry.Registry that registers a server instance under a
+// lease in etcd, so the key disappears on its own (after Config.TTL) if the
+// process dies without deregistering.
+type Registry struct {
+	client *clientv3.Client
+	cfg    Config
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc // key -> stop func for its lease keepalive
+}
+
+// NewEtcdRegistry builds a registry.Registry backed by the etcd v3 cluster
+// described by cfg.
+func NewEtcdRegistry(cfg Config, opts ...Option) (*Registry, error) {
+	cfg = cfg.withDefaults()
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: cfg.DialTimeout,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		TLS:         cfg.TLSConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Registry{
+		client:  cli,
+		cfg:     cfg,
+		cancels: make(map[string]context.CancelFunc),
+	}, nil
+}
+
+// Register implements registry.Registry. It grants a lease for Config.TTL,
+// writes the instance under it, and keeps the lease alive in the background
+// until Deregister is called.
+func (r *Registry) Register(info *registry.Info) error {
+	key := instanceKey(r.cfg.Prefix, info.ServiceName, info.Addr.String())
+	value, err := encodeInstance(info.Weight, info.Tags)
+	if err != nil {
+		return err
+	}
+
+	ttlSeconds := int64(r.cfg.TTL.Seconds())
+	lease, err := r.client.Grant(context.Background(), ttlSeconds)
+	if err != nil {
+		return err
+	}
+	if _, err := r.client.Put(context.Background(), key, string(value), clientv3.WithLease(lease.ID)); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	keepAlive, err := r.client.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		cancel()
+		return err
+	}
+	go func() {
+		for range keepAlive {
+			// drain the channel; nothing to do for a successful renewal.
+		}
+	}()
+
+	r.mu.Lock()
+	r.cancels[key] = cancel
+	r.mu.Unlock()
+	return nil
+}
+
+// Deregister implements registry.Registry. It stops the lease keepalive and
+// deletes the key, so the instance disappears immediately instead of
+// waiting out the TTL.
+func (r *Registry) Deregister(info *registry.Info) error {
+	key := instanceKey(r.cfg.Prefix, info.ServiceName, info.Addr.String())
+
+	r.mu.Lock()
+	cancel, ok := r.cancels[key]
+	delete(r.cancels, key)
+	r.mu.Unlock()
+	if ok {
+		cancel()
+	}
+
+	if _, err := r.client.Delete(context.Background(), key); err != nil {
+		klog.Warnf("KITEX: etcd registry failed to delete %s: %v", key, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// resolver is a discovery.Resolver backed by an etcd v3 cluster. Instances
+// are listed once under Prefix/{serviceName} and then kept up to date via
+// etcd's Watch API, so Resolve never needs to re-poll the cluster.
+type resolver struct {
+	client *clientv3.Client
+	cfg    Config
+	opts   *options
+
+	mu      sync.RWMutex
+	cache   map[string][]discovery.Instance // serviceName -> instances
+	started map[string]bool                 // serviceName -> watch goroutine started
+}
+
+// NewEtcdResolver builds a discovery.Resolver backed by the etcd v3 cluster
+// described by cfg.
+func NewEtcdResolver(cfg Config, opts ...Option) (discovery.Resolver, error) {
+	cfg = cfg.withDefaults()
+
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: cfg.DialTimeout,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		TLS:         cfg.TLSConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolver{
+		client:  cli,
+		cfg:     cfg,
+		opts:    newOptions(opts),
+		cache:   make(map[string][]discovery.Instance),
+		started: make(map[string]bool),
+	}, nil
+}
+
+// Target implements discovery.Resolver.
+func (r *resolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+// Resolve implements discovery.Resolver. The first call for a given service
+// lists the current instances under the service's prefix and starts a
+// background Watch, from the revision right after that list, to keep them
+// current; subsequent calls are served from the in-memory cache kept fresh
+// by that watch.
+func (r *resolver) Resolve(ctx context.Context, serviceName string) (discovery.Result, error) {
+	ins, err := r.ensureWatching(ctx, serviceName)
+	if err != nil {
+		return discovery.Result{}, err
+	}
+	if ins == nil {
+		r.mu.RLock()
+		ins = append([]discovery.Instance(nil), r.cache[serviceName]...)
+		r.mu.RUnlock()
+	}
+
+	return discovery.Result{
+		Cacheable: true,
+		CacheKey:  serviceName,
+		Instances: ins,
+	}, nil
+}
+
+// Diff implements discovery.Resolver.
+func (r *resolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+// Name implements discovery.Resolver.
+func (r *resolver) Name() string {
+	return "etcd"
+}
+
+func (r *resolver) servicePrefix(serviceName string) string {
+	return r.cfg.Prefix + "/" + serviceName + "/"
+}
+
+// list reads the current instances under serviceName's prefix and also
+// returns the revision of that read, so the caller can start a Watch from
+// the very next revision instead of an unsynchronized "now".
+func (r *resolver) list(ctx context.Context, serviceName string) ([]discovery.Instance, int64, error) {
+	prefix := r.servicePrefix(serviceName)
+	opts := append([]clientv3.OpOption{clientv3.WithPrefix()}, r.opts.etcdOpts...)
+	resp, err := r.client.Get(ctx, prefix, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ins := make([]discovery.Instance, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		instance, ok := r.toInstance(prefix, kv.Key, kv.Value)
+		if ok {
+			ins = append(ins, instance)
+		}
+	}
+	return ins, resp.Header.Revision, nil
+}
+
+func (r *resolver) toInstance(prefix string, key, value []byte) (discovery.Instance, bool) {
+	hostPort := strings.TrimPrefix(string(key), prefix)
+	if hostPort == "" {
+		return nil, false
+	}
+	info, err := decodeInstance(value)
+	if err != nil {
+		klog.Warnf("KITEX: etcd resolver failed to decode instance %s: %v", key, err)
+		return nil, false
+	}
+	weight := info.Weight
+	if weight <= 0 {
+		weight = discovery.DefaultWeight
+	}
+	return discovery.NewInstance("tcp", hostPort, weight, info.Tags), true
+}
+
+// ensureWatching performs the initial list for serviceName and starts its
+// watch goroutine exactly once, from the revision right after that list, so
+// no update between the list and the watch's start can be lost or
+// overwritten. It returns the freshly listed instances on the call that
+// actually did the work (nil on every later call, which should read the
+// cache instead).
+func (r *resolver) ensureWatching(ctx context.Context, serviceName string) ([]discovery.Instance, error) {
+	r.mu.Lock()
+	if r.started[serviceName] {
+		r.mu.Unlock()
+		return nil, nil
+	}
+	r.started[serviceName] = true
+	r.mu.Unlock()
+
+	ins, rev, err := r.list(ctx, serviceName)
+	if err != nil {
+		r.mu.Lock()
+		delete(r.started, serviceName)
+		r.mu.Unlock()
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[serviceName] = ins
+	r.mu.Unlock()
+
+	go r.watch(serviceName, rev+1)
+	return ins, nil
+}
+
+// watch runs until the etcd watch channel closes (e.g. ErrCompacted, or the
+// server cancelling the watch), then clears started[serviceName] so the next
+// Resolve relists and starts a fresh watch instead of this goroutine leaving
+// the cache frozen forever with no way to recover.
+func (r *resolver) watch(serviceName string, rev int64) {
+	prefix := r.servicePrefix(serviceName)
+	wc := r.client.Watch(context.Background(), prefix, clientv3.WithPrefix(), clientv3.WithRev(rev))
+	for resp := range wc {
+		if resp.Err() != nil {
+			klog.Warnf("KITEX: etcd resolver watch on %s failed: %v", prefix, resp.Err())
+			continue
+		}
+		r.applyEvents(serviceName, prefix, resp.Events)
+	}
+
+	klog.Warnf("KITEX: etcd resolver watch on %s closed, will relist on next Resolve", prefix)
+	r.mu.Lock()
+	delete(r.started, serviceName)
+	r.mu.Unlock()
+}
+
+func (r *resolver) applyEvents(serviceName, prefix string, events []*clientv3.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ins := append([]discovery.Instance(nil), r.cache[serviceName]...)
+	for _, ev := range events {
+		hostPort := strings.TrimPrefix(string(ev.Kv.Key), prefix)
+		if hostPort == "" {
+			continue
+		}
+		ins = removeInstance(ins, hostPort)
+		if ev.Type == clientv3.EventTypePut {
+			if instance, ok := r.toInstance(prefix, ev.Kv.Key, ev.Kv.Value); ok {
+				ins = append(ins, instance)
+			}
+		}
+	}
+	r.cache[serviceName] = ins
+}
+
+func removeInstance(ins []discovery.Instance, hostPort string) []discovery.Instance {
+	out := ins[:0]
+	for _, in := range ins {
+		if in.Address().String() != hostPort {
+			out = append(out, in)
+		}
+	}
+	return out
+}
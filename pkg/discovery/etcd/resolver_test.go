@@ -0,0 +1,100 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"testing"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+)
+
+func newTestResolver() *resolver {
+	return &resolver{
+		cfg:     Config{Prefix: "/kitex"},
+		cache:   make(map[string][]discovery.Instance),
+		started: make(map[string]bool),
+	}
+}
+
+func TestResolver_ToInstance(t *testing.T) {
+	r := newTestResolver()
+	prefix := r.servicePrefix("echo")
+
+	value, err := encodeInstance(10, map[string]string{"idc": "us-west"})
+	if err != nil {
+		t.Fatalf("encodeInstance failed: %v", err)
+	}
+
+	ins, ok := r.toInstance(prefix, []byte(prefix+"127.0.0.1:8888"), value)
+	if !ok {
+		t.Fatal("expected toInstance to succeed")
+	}
+	if got := ins.Address().String(); got != "127.0.0.1:8888" {
+		t.Fatalf("expected address 127.0.0.1:8888, got %s", got)
+	}
+}
+
+func TestResolver_ToInstance_MalformedValueIsSkipped(t *testing.T) {
+	r := newTestResolver()
+	prefix := r.servicePrefix("echo")
+
+	_, ok := r.toInstance(prefix, []byte(prefix+"127.0.0.1:8888"), []byte("not json"))
+	if ok {
+		t.Fatal("expected toInstance to reject undecodable data")
+	}
+}
+
+func TestResolver_ApplyEvents_PutThenDelete(t *testing.T) {
+	r := newTestResolver()
+	serviceName := "echo"
+	prefix := r.servicePrefix(serviceName)
+	key := prefix + "127.0.0.1:8888"
+
+	value, _ := encodeInstance(discovery.DefaultWeight, nil)
+	r.applyEvents(serviceName, prefix, []*clientv3.Event{
+		{Type: clientv3.EventTypePut, Kv: &mvccpb.KeyValue{Key: []byte(key), Value: value}},
+	})
+
+	if got := len(r.cache[serviceName]); got != 1 {
+		t.Fatalf("expected 1 instance after put, got %d", got)
+	}
+
+	r.applyEvents(serviceName, prefix, []*clientv3.Event{
+		{Type: clientv3.EventTypeDelete, Kv: &mvccpb.KeyValue{Key: []byte(key)}},
+	})
+
+	if got := len(r.cache[serviceName]); got != 0 {
+		t.Fatalf("expected 0 instances after delete, got %d", got)
+	}
+}
+
+func TestRemoveInstance(t *testing.T) {
+	r := newTestResolver()
+	prefix := r.servicePrefix("echo")
+	value, _ := encodeInstance(discovery.DefaultWeight, nil)
+
+	a, _ := r.toInstance(prefix, []byte(prefix+"127.0.0.1:1"), value)
+	b, _ := r.toInstance(prefix, []byte(prefix+"127.0.0.1:2"), value)
+
+	out := removeInstance([]discovery.Instance{a, b}, "127.0.0.1:1")
+	if len(out) != 1 || out[0].Address().String() != "127.0.0.1:2" {
+		t.Fatalf("expected only 127.0.0.1:2 to remain, got %v", out)
+	}
+}
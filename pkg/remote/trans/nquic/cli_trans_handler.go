@@ -0,0 +1,75 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"context"
+	"net"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// cliTransHandler implements remote.ClientTransHandler on top of a QUIC
+// stream. Framing and payload codec work is delegated to opt.Codec, the same
+// as every other kitex transport; the only thing specific to QUIC here is
+// that conn is always a *streamConn wrapping a *SafeStream.
+type cliTransHandler struct {
+	quicOpt *ClientOption
+	opt     *remote.ClientOption
+}
+
+func newCliTransHandler(quicOpt *ClientOption, opt *remote.ClientOption) (*cliTransHandler, error) {
+	return &cliTransHandler{quicOpt: quicOpt, opt: opt}, nil
+}
+
+// Write encodes and writes msg to conn using the configured codec.
+func (h *cliTransHandler) Write(ctx context.Context, conn net.Conn, send remote.Message) (context.Context, error) {
+	out := remote.NewWriterBuffer(conn)
+	if err := h.opt.Codec.Encode(ctx, send, out); err != nil {
+		return ctx, err
+	}
+	return ctx, out.Flush()
+}
+
+// Read reads and decodes a response from conn using the configured codec.
+func (h *cliTransHandler) Read(ctx context.Context, conn net.Conn, msg remote.Message) (context.Context, error) {
+	in := remote.NewReaderBuffer(conn)
+	return ctx, h.opt.Codec.Decode(ctx, msg, in)
+}
+
+// OnInactive cancels the stream so that abandoning one direction of an RPC
+// (e.g. a oneway call, or a caller that stopped reading) doesn't leak the
+// peer's corresponding send side.
+func (h *cliTransHandler) OnInactive(ctx context.Context, conn net.Conn) {
+	_ = conn.Close()
+}
+
+// OnError closes conn when the rest of the client stack surfaces an error;
+// it does not log err.
+func (h *cliTransHandler) OnError(ctx context.Context, err error, conn net.Conn) {
+	_ = conn.Close()
+}
+
+// OnMessage is a no-op for the client side; kept to satisfy
+// remote.ClientTransHandler, same as nphttp2's handler.
+func (h *cliTransHandler) OnMessage(ctx context.Context, args, result remote.Message) (context.Context, error) {
+	return ctx, nil
+}
+
+// SetPipeline is part of remote.TransHandler but unused by kitex's client
+// transports; kept for interface compliance.
+func (h *cliTransHandler) SetPipeline(p *remote.TransPipeline) {}
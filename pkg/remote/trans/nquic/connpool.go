@@ -0,0 +1,125 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// connPool dials one quic.Connection per remote address and hands out a new
+// stream (wrapped in a SafeStream) per RPC, mirroring the one-conn-many-
+// streams model nphttp2 uses for HTTP/2.
+type connPool struct {
+	opt *ClientOption
+
+	mu    sync.Mutex
+	conns map[string]quic.Connection
+}
+
+// NewConnPool creates a QUIC-backed remote.ConnPool using opt for dialing.
+func NewConnPool(opt *ClientOption) *connPool {
+	if opt == nil {
+		opt = NewClientOption()
+	}
+	return &connPool{
+		opt:   opt,
+		conns: make(map[string]quic.Connection),
+	}
+}
+
+// Get returns a net.Conn backed by a new QUIC stream on the connection to
+// network/address, dialing and caching the underlying quic.Connection on
+// first use.
+func (p *connPool) Get(ctx context.Context, network, address string, opt remote.ConnOption) (net.Conn, error) {
+	conn, err := p.getConnection(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	s, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &streamConn{SafeStream: NewSafeStream(s), local: conn.LocalAddr(), remote: conn.RemoteAddr()}, nil
+}
+
+func (p *connPool) getConnection(ctx context.Context, network, address string) (quic.Connection, error) {
+	p.mu.Lock()
+	if c, ok := p.conns[address]; ok {
+		// A quic.Connection's Context is cancelled once the connection is
+		// closed (idle timeout, network blip, peer reset, ...). Trusting a
+		// dead entry forever would make every subsequent Get for address
+		// fail OpenStreamSync permanently, so evict it and fall through to
+		// redial instead of returning it.
+		if c.Context().Err() == nil {
+			p.mu.Unlock()
+			return c, nil
+		}
+		delete(p.conns, address)
+	}
+	p.mu.Unlock()
+
+	c, err := quic.DialAddr(ctx, address, p.opt.TLSConfig, p.opt.QUICConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if existing, ok := p.conns[address]; ok && existing.Context().Err() == nil {
+		_ = c.CloseWithError(0, "redundant dial")
+		return existing, nil
+	}
+	p.conns[address] = c
+	return c, nil
+}
+
+// Put closes the per-RPC stream returned by Get. Streams aren't reused
+// across RPCs (the underlying quic.Connection is what's pooled, via
+// getConnection), so a successful RPC must close its stream here or it
+// leaks until the connection's idle timeout — and once enough leak,
+// OpenStreamSync blocks forever on the peer's stream concurrency limit.
+func (p *connPool) Put(conn net.Conn) error { return conn.Close() }
+
+// Discard closes the stream without returning it for reuse.
+func (p *connPool) Discard(conn net.Conn) error { return conn.Close() }
+
+// Close tears down every pooled quic.Connection.
+func (p *connPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, c := range p.conns {
+		_ = c.CloseWithError(0, "client closed")
+		delete(p.conns, addr)
+	}
+	return nil
+}
+
+// streamConn adapts a *SafeStream plus the parent quic.Connection's
+// addresses into a net.Conn.
+type streamConn struct {
+	*SafeStream
+	local, remote net.Addr
+}
+
+func (c *streamConn) LocalAddr() net.Addr  { return c.local }
+func (c *streamConn) RemoteAddr() net.Addr { return c.remote }
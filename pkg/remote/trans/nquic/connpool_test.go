@@ -0,0 +1,105 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/quic-go/quic-go"
+)
+
+// fakeConn is a net.Conn stand-in that only tracks Close calls, enough to
+// verify connPool hands the stream's lifecycle off correctly.
+type fakeConn struct {
+	net.Conn
+	closes int
+}
+
+func (c *fakeConn) Close() error {
+	c.closes++
+	return nil
+}
+
+// TestConnPool_PutClosesStream pins down the bug where Put was a no-op:
+// since streams aren't reused across RPCs, a successful RPC's Put must
+// close its stream or it leaks until the connection's idle timeout, and
+// eventually exhausts the peer's stream concurrency limit.
+func TestConnPool_PutClosesStream(t *testing.T) {
+	p := &connPool{}
+	c := &fakeConn{}
+
+	if err := p.Put(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.closes != 1 {
+		t.Fatalf("expected Put to close the stream exactly once, got %d", c.closes)
+	}
+}
+
+func TestConnPool_DiscardClosesStream(t *testing.T) {
+	p := &connPool{}
+	c := &fakeConn{}
+
+	if err := p.Discard(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.closes != 1 {
+		t.Fatalf("expected Discard to close the stream exactly once, got %d", c.closes)
+	}
+}
+
+// fakeQuicConn implements quic.Connection by embedding the (nil) interface
+// and overriding only Context, enough to control connPool's liveness check
+// without a real QUIC connection.
+type fakeQuicConn struct {
+	quic.Connection
+	ctx context.Context
+}
+
+func (f *fakeQuicConn) Context() context.Context { return f.ctx }
+
+// TestConnPool_GetConnection_EvictsDeadConnection pins down the bug where a
+// cached quic.Connection whose Context was already done (idle timeout,
+// network blip, ...) was trusted forever, permanently breaking every future
+// Get for that address once OpenStreamSync started failing on it.
+func TestConnPool_GetConnection_EvictsDeadConnection(t *testing.T) {
+	deadCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := NewConnPool(nil)
+	p.conns["addr"] = &fakeQuicConn{ctx: deadCtx}
+
+	// The redial itself can't succeed without a real QUIC server, but an
+	// already-cancelled dial context makes it fail fast, letting us assert
+	// on the eviction instead of needing a live endpoint.
+	dialCtx, dialCancel := context.WithCancel(context.Background())
+	dialCancel()
+
+	_, err := p.getConnection(dialCtx, "udp", "addr")
+	if err == nil {
+		t.Fatal("expected the redial to fail against a cancelled dial context")
+	}
+
+	p.mu.Lock()
+	_, stillCached := p.conns["addr"]
+	p.mu.Unlock()
+	if stillCached {
+		t.Fatal("expected the dead connection to have been evicted from the cache")
+	}
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"crypto/tls"
+
+	"github.com/quic-go/quic-go"
+)
+
+// ClientOption configures the QUIC connection pool and transport handler
+// factory returned by NewConnPool / NewCliTransHandlerFactory.
+type ClientOption struct {
+	QUICConfig *quic.Config
+	TLSConfig  *tls.Config
+}
+
+// NewClientOption returns a ClientOption with kitex's defaults. TLSConfig is
+// left nil; callers almost always need to set at least NextProtos for QUIC,
+// so client.WithQUICConfig is the option that fills it in.
+func NewClientOption() *ClientOption {
+	return &ClientOption{
+		QUICConfig: &quic.Config{},
+	}
+}
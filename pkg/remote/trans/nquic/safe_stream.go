@@ -0,0 +1,78 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+)
+
+// noError is the application-level error code used when cancelling the receive
+// side of a stream on shutdown. It carries no semantic meaning to the peer
+// beyond "this stream is done".
+const noError quic.StreamErrorCode = 0
+
+// errStreamClosed is returned by Write after Close has already been called.
+var errStreamClosed = errors.New("nquic: write on closed stream")
+
+// SafeStream wraps a quic.Stream so it can be used the way kitex's transport
+// handlers expect a single bidirectional net.Conn-like stream to behave.
+//
+// quic.Stream is actually a SendStream and a ReceiveStream glued together, and
+// quic-go forbids calling Close concurrently with Write on the same stream.
+// SafeStream serializes the two under a mutex and makes repeated Close calls
+// a no-op, so transport handlers that close a stream from a different
+// goroutine than the one writing to it (e.g. on context cancellation) can't
+// race or panic.
+type SafeStream struct {
+	quic.Stream
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewSafeStream wraps s for safe concurrent use by kitex's transport layer.
+func NewSafeStream(s quic.Stream) *SafeStream {
+	return &SafeStream{Stream: s}
+}
+
+// Write serializes writes against concurrent Close calls.
+func (s *SafeStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, errStreamClosed
+	}
+	return s.Stream.Write(p)
+}
+
+// Close closes the send side exactly once and cancels the receive side so
+// the peer's send side is torn down immediately instead of leaking until the
+// connection's idle timeout fires.
+func (s *SafeStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.Stream.Close()
+	s.Stream.CancelRead(noError)
+	return err
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/quic-go/quic-go"
+)
+
+// fakeStream implements quic.Stream by embedding the (nil) interface and
+// overriding only the methods SafeStream actually calls, which is enough to
+// exercise SafeStream without a real QUIC connection.
+type fakeStream struct {
+	quic.Stream
+
+	mu         sync.Mutex
+	writes     int
+	closes     int
+	cancelRead int
+}
+
+func (f *fakeStream) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes++
+	return len(p), nil
+}
+
+func (f *fakeStream) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closes++
+	return nil
+}
+
+func (f *fakeStream) CancelRead(quic.StreamErrorCode) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelRead++
+}
+
+func TestSafeStream_CloseIsIdempotent(t *testing.T) {
+	f := &fakeStream{}
+	s := NewSafeStream(f)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close should be a no-op, got error: %v", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closes != 1 {
+		t.Fatalf("expected exactly 1 underlying Close, got %d", f.closes)
+	}
+	if f.cancelRead != 1 {
+		t.Fatalf("expected exactly 1 CancelRead, got %d", f.cancelRead)
+	}
+}
+
+func TestSafeStream_WriteAfterCloseFails(t *testing.T) {
+	f := &fakeStream{}
+	s := NewSafeStream(f)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Write([]byte("hi")); err != errStreamClosed {
+		t.Fatalf("expected errStreamClosed, got %v", err)
+	}
+}
+
+func TestSafeStream_ConcurrentWriteAndClose(t *testing.T) {
+	f := &fakeStream{}
+	s := NewSafeStream(f)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = s.Write([]byte("x"))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = s.Close()
+		}()
+	}
+	wg.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closes != 1 {
+		t.Fatalf("expected exactly 1 underlying Close despite concurrent callers, got %d", f.closes)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nquic
+
+import (
+	"github.com/cloudwego/kitex/pkg/remote"
+)
+
+// cliTransHandlerFactory builds remote.ClientTransHandler instances that
+// speak kitex's payload framing over a QUIC stream.
+type cliTransHandlerFactory struct {
+	opt *ClientOption
+}
+
+// NewCliTransHandlerFactory returns a remote.ClientTransHandlerFactory for
+// the QUIC transport, analogous to nphttp2.NewCliTransHandlerFactory.
+func NewCliTransHandlerFactory(opt *ClientOption) remote.ClientTransHandlerFactory {
+	if opt == nil {
+		opt = NewClientOption()
+	}
+	return &cliTransHandlerFactory{opt: opt}
+}
+
+// NewTransHandler implements remote.ClientTransHandlerFactory.
+func (f *cliTransHandlerFactory) NewTransHandler(opt *remote.ClientOption) (remote.ClientTransHandler, error) {
+	return newCliTransHandler(f.opt, opt)
+}
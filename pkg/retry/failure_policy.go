@@ -0,0 +1,73 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import "time"
+
+// FailurePolicy retries a failed RPC up to StopPolicy.MaxRetryTimes times.
+type FailurePolicy struct {
+	StopPolicy        StopPolicy
+	BackOffPolicy     *BackOffPolicy
+	RetrySameNode     bool
+	ShouldResultRetry *ShouldResultRetry
+}
+
+// BackupPolicy fires a second, identical request after RetryDelay if the
+// first hasn't returned yet, and takes whichever response comes back first.
+type BackupPolicy struct {
+	RetryDelay    time.Duration
+	StopPolicy    StopPolicy
+	RetrySameNode bool
+}
+
+// StopPolicy bounds how many attempts and how much time a retry policy may
+// spend on a single RPC.
+type StopPolicy struct {
+	MaxRetryTimes    int32
+	MaxDurationMS    uint32
+	DisableChainStop bool
+}
+
+// BackOffPolicy describes the delay between failure-retry attempts.
+type BackOffPolicy struct {
+	BackOffType BackOffType
+	CfgItems    map[BackOffCfgKey]float64
+}
+
+// BackOffType selects how the delay between attempts is computed.
+type BackOffType string
+
+// back off types
+const (
+	FixedBackOffType BackOffType = "fixed"
+	NoneBackOffType  BackOffType = "none"
+)
+
+// BackOffCfgKey keys into BackOffPolicy.CfgItems.
+type BackOffCfgKey string
+
+// back off config keys
+const (
+	FixMSBackOffCfgKey BackOffCfgKey = "fix_ms"
+)
+
+// ShouldResultRetry lets callers retry on specific business error/resp
+// shapes, not just transport-level failures.
+type ShouldResultRetry struct {
+	ErrorRetry func(err error, ctx interface{}) bool
+	RespRetry  func(resp interface{}, ctx interface{}) bool
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// hedgingExecutor runs a single call under a HedgingPolicy. It's built fresh
+// per Policy (via NewHedgingExecutor) and reused across calls, so its
+// latency window and in-flight counter reflect the whole client's traffic.
+type hedgingExecutor struct {
+	policy *HedgingPolicy
+
+	mu      sync.Mutex
+	samples []time.Duration // ring buffer of recent successful-RPC latencies
+	next    int
+
+	inFlight int32 // hedge attempts (excluding the original) currently running
+}
+
+// NewHedgingExecutor builds the middleware-facing executor for p. p is not
+// copied, so later mutation of *p after the client starts serving traffic
+// has undefined effect on in-flight calls.
+func NewHedgingExecutor(p *HedgingPolicy) *hedgingExecutor {
+	return &hedgingExecutor{
+		policy:  p,
+		samples: make([]time.Duration, 0, p.WindowSize),
+	}
+}
+
+// Middleware wraps next so that instead of issuing one attempt, it issues up
+// to policy.MaxAttempts attempts staggered by the adaptive delay, and
+// returns as soon as any of them completes without a retryable error. Every
+// other in-flight sibling is cancelled at that point through the attempt
+// context they all share.
+func (h *hedgingExecutor) Middleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		ri := rpcinfo.GetRPCInfo(ctx)
+		if h.policy.ShouldHedge != nil && !h.policy.ShouldHedge(ri) {
+			return next(ctx, req, resp)
+		}
+
+		type attemptResult struct {
+			err  error
+			resp interface{}
+		}
+
+		resultCh := make(chan attemptResult, h.policy.MaxAttempts)
+		attemptCtx, cancelAll := context.WithCancel(ctx)
+		defer cancelAll()
+
+		launch := func(isHedge bool) {
+			// Each attempt decodes into its own response object — sharing resp
+			// across concurrent attempts races two goroutines unmarshalling
+			// into the same struct. Only the winner's copy is written back
+			// into the caller's resp, below.
+			attemptResp := cloneResp(resp)
+			go func() {
+				start := time.Now()
+				err := next(attemptCtx, req, attemptResp)
+				if err == nil {
+					h.recordLatency(time.Since(start))
+				}
+				if isHedge {
+					h.releaseHedge()
+				}
+				resultCh <- attemptResult{err: err, resp: attemptResp}
+			}()
+		}
+
+		launch(false)
+		launched, received := 1, 0
+		var lastErr error
+		delay := h.staggerDelay()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		for {
+			select {
+			case res := <-resultCh:
+				received++
+				if res.err == nil || !isRetryable(res.err) {
+					if res.err == nil {
+						copyResp(resp, res.resp)
+					}
+					return res.err
+				}
+				lastErr = res.err
+				// Only give up once every attempt launched so far has
+				// reported back and no more will be launched — a retryable
+				// failure from one attempt must not cut off siblings that
+				// are still racing in flight.
+				if received >= launched && launched >= h.policy.MaxAttempts {
+					return lastErr
+				}
+			case <-timer.C:
+				if launched < h.policy.MaxAttempts && h.tryAcquireHedge() {
+					launch(true)
+					launched++
+				}
+				timer.Reset(delay)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (h *hedgingExecutor) tryAcquireHedge() bool {
+	if h.policy.MaxConcurrentHedges <= 0 {
+		return true
+	}
+	for {
+		cur := atomic.LoadInt32(&h.inFlight)
+		if int(cur) >= h.policy.MaxConcurrentHedges {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&h.inFlight, cur, cur+1) {
+			return true
+		}
+	}
+}
+
+// releaseHedge returns one slot acquired by tryAcquireHedge, called once a
+// hedge attempt (not the original) finishes. Without this, inFlight would
+// only ever grow for the lifetime of the long-lived hedgingExecutor, turning
+// MaxConcurrentHedges into a one-time lifetime budget instead of a
+// concurrency cap.
+func (h *hedgingExecutor) releaseHedge() {
+	atomic.AddInt32(&h.inFlight, -1)
+}
+
+func (h *hedgingExecutor) recordLatency(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) < cap(h.samples) {
+		h.samples = append(h.samples, d)
+	} else {
+		h.samples[h.next] = d
+		h.next = (h.next + 1) % len(h.samples)
+	}
+}
+
+// staggerDelay returns the LatencyPercentile of the recorded window, floored
+// at policy.MinDelay. With no samples yet it returns MinDelay, so the first
+// calls through a fresh executor hedge on a fixed schedule until enough
+// successful RPCs have been observed to estimate real latency.
+func (h *hedgingExecutor) staggerDelay() time.Duration {
+	h.mu.Lock()
+	samples := append([]time.Duration(nil), h.samples...)
+	h.mu.Unlock()
+
+	if len(samples) == 0 {
+		return h.policy.MinDelay
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	idx := int(h.policy.LatencyPercentile / 100 * float64(len(samples)-1))
+	d := samples[idx]
+	if d < h.policy.MinDelay {
+		return h.policy.MinDelay
+	}
+	return d
+}
+
+// isRetryable reports whether err should trigger abandoning a hedge's
+// sibling attempts rather than racing the remaining ones to completion.
+// An error is non-retryable, and wins immediately, only if it implements
+// Retryable() bool and that returns false; every other error, including one
+// without a Retryable method, lets the other attempts keep racing.
+func isRetryable(err error) bool {
+	if re, ok := err.(interface{ Retryable() bool }); ok {
+		return re.Retryable()
+	}
+	return true
+}
+
+// cloneResp returns a fresh zero-value instance of resp's concrete type, so
+// a hedge attempt can decode into it without racing the original attempt
+// (or any other sibling) over the caller's resp. kitex response objects are
+// always pointers, so this is just reflect.New of the pointed-to type; any
+// other shape is returned unchanged since there's nothing to race over.
+func cloneResp(resp interface{}) interface{} {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr {
+		return resp
+	}
+	return reflect.New(v.Type().Elem()).Interface()
+}
+
+// copyResp writes the winning attempt's decoded response (src, as produced
+// by cloneResp) back into the caller's original dst.
+func copyResp(dst, src interface{}) {
+	dv := reflect.ValueOf(dst)
+	sv := reflect.ValueOf(src)
+	if dv.Kind() != reflect.Ptr || sv.Kind() != reflect.Ptr {
+		return
+	}
+	dv.Elem().Set(sv.Elem())
+}
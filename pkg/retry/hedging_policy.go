@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// HedgingPolicy implements the hedged-request retry strategy: fire up to
+// MaxAttempts parallel attempts, staggered by a delay that tracks observed
+// latency, and take whichever non-retryable response comes back first.
+//
+// Unlike BackupPolicy's fixed RetryDelay, the stagger delay here adapts to
+// the LatencyPercentile observed over the last WindowSize successful RPCs,
+// so hedges fire roughly when a request is already running unusually slow
+// instead of after an arbitrary fixed wait.
+type HedgingPolicy struct {
+	// MaxAttempts is the maximum number of in-flight attempts for a single
+	// call, including the original. Must be >= 2.
+	MaxAttempts int
+
+	// MinDelay is the minimum stagger delay between attempts, regardless of
+	// what the adaptive latency estimate says. Protects the backend from a
+	// burst of hedges when recent latency happens to be very low.
+	MinDelay time.Duration
+
+	// LatencyPercentile is the percentile (e.g. 99 for p99) of recent
+	// successful-RPC latency used to compute the adaptive stagger delay.
+	LatencyPercentile float64
+
+	// WindowSize is the number of recent successful RPCs the latency
+	// percentile is computed over. The estimate is a plain percentile of that
+	// window; the samples are not exponentially weighted.
+	WindowSize int
+
+	// ShouldHedge, if set, is consulted once per call, before the first
+	// attempt; returning false runs that call as a single attempt with no
+	// hedges (e.g. to skip hedging for RPCs already flagged non-idempotent).
+	ShouldHedge func(rpcinfo.RPCInfo) bool
+
+	// MaxConcurrentHedges caps how many hedge attempts (beyond the original)
+	// may be in flight across the whole client at once, so a latency spike
+	// can't multiply load on an already-struggling backend.
+	MaxConcurrentHedges int
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type retryableErr struct{ error }
+
+func (retryableErr) Retryable() bool { return true }
+
+func newTestPolicy() *HedgingPolicy {
+	return &HedgingPolicy{
+		MaxAttempts:         3,
+		MinDelay:            time.Millisecond,
+		LatencyPercentile:   99,
+		WindowSize:          16,
+		MaxConcurrentHedges: 2,
+	}
+}
+
+func TestHedgingExecutor_FirstAttemptSucceeds(t *testing.T) {
+	h := NewHedgingExecutor(newTestPolicy())
+	var calls int32
+	mw := h.Middleware(func(ctx context.Context, req, resp interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if err := mw(context.Background(), nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected exactly 1 attempt, got %d", got)
+	}
+}
+
+// TestHedgingExecutor_WaitsForAllLaunchedAttempts pins down the bug where a
+// retryable failure from one attempt, once MaxAttempts had been launched,
+// short-circuited the call even though another launched attempt was about
+// to succeed.
+func TestHedgingExecutor_WaitsForAllLaunchedAttempts(t *testing.T) {
+	p := newTestPolicy()
+	p.MaxAttempts = 2
+	p.MinDelay = time.Millisecond
+	h := NewHedgingExecutor(p)
+
+	var calls int32
+	mw := h.Middleware(func(ctx context.Context, req, resp interface{}) error {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			// First attempt fails with a retryable error, but only after the
+			// hedge (launched at MinDelay) is already in flight.
+			time.Sleep(5 * time.Millisecond)
+			return retryableErr{errors.New("transient")}
+		}
+		// Second (hedge) attempt is still racing; give it time to be
+		// in flight when the first result arrives.
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	err := mw(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("expected the in-flight sibling's success to win, got error: %v", err)
+	}
+}
+
+// TestHedgingExecutor_EachAttemptGetsOwnResponse pins down the bug where
+// every attempt decoded into the same caller-supplied resp, racing each
+// other; the winner's response must be the one copied back.
+func TestHedgingExecutor_EachAttemptGetsOwnResponse(t *testing.T) {
+	type resp struct{ val int32 }
+
+	p := newTestPolicy()
+	p.MaxAttempts = 2
+	p.MinDelay = time.Millisecond
+	h := NewHedgingExecutor(p)
+
+	var calls int32
+	mw := h.Middleware(func(ctx context.Context, req, r interface{}) error {
+		n := atomic.AddInt32(&calls, 1)
+		out := r.(*resp)
+		if n == 1 {
+			// First attempt is slow; its write must not land in the
+			// caller's resp once the faster hedge has already won.
+			time.Sleep(20 * time.Millisecond)
+			out.val = 1
+			return nil
+		}
+		out.val = 2
+		return nil
+	})
+
+	got := &resp{}
+	if err := mw(context.Background(), nil, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.val != 2 {
+		t.Fatalf("expected the winning (faster) attempt's response, got val=%d", got.val)
+	}
+}
+
+func TestHedgingExecutor_AllAttemptsFail(t *testing.T) {
+	p := newTestPolicy()
+	p.MaxAttempts = 2
+	p.MinDelay = time.Millisecond
+	h := NewHedgingExecutor(p)
+
+	wantErr := retryableErr{errors.New("boom")}
+	mw := h.Middleware(func(ctx context.Context, req, resp interface{}) error {
+		return wantErr
+	})
+
+	err := mw(context.Background(), nil, nil)
+	if err == nil {
+		t.Fatal("expected an error when every launched attempt fails")
+	}
+}
+
+// TestHedgingExecutor_ReleasesSlot pins down the bug where tryAcquireHedge
+// incremented inFlight but nothing ever released it, turning
+// MaxConcurrentHedges into a one-time lifetime budget on a long-lived
+// executor instead of a concurrency cap.
+func TestHedgingExecutor_ReleasesSlot(t *testing.T) {
+	p := newTestPolicy()
+	p.MaxAttempts = 2
+	p.MaxConcurrentHedges = 1
+	p.MinDelay = time.Millisecond
+	h := NewHedgingExecutor(p)
+
+	mw := h.Middleware(func(ctx context.Context, req, resp interface{}) error {
+		// Outlast MinDelay so every call actually launches a hedge and
+		// acquires a slot.
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		if err := mw(context.Background(), nil, nil); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+	// Let the last call's losing hedge finish and release its slot.
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&h.inFlight); got != 0 {
+		t.Fatalf("expected inFlight to settle back to 0, got %d", got)
+	}
+}
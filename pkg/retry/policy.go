@@ -0,0 +1,43 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package retry defines kitex's client-side retry policies: failure retry,
+// backup request, and hedged request. Exactly one of them may be enabled on
+// a given Policy.
+package retry
+
+// Type identifies which retry strategy a Policy carries.
+type Type int
+
+// retry types
+const (
+	FailureType Type = iota
+	BackupType
+	HedgingType
+)
+
+// Policy is the retry configuration threaded through client.Options. Only
+// one of FailurePolicy, BackupPolicy, HedgingPolicy may be set; the
+// WithFailureRetry/WithBackupRequest/WithHedgedRequest options each panic if
+// another one has already been configured.
+type Policy struct {
+	Enable bool
+	Type   Type
+
+	FailurePolicy *FailurePolicy
+	BackupPolicy  *BackupPolicy
+	HedgingPolicy *HedgingPolicy
+}
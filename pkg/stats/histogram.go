@@ -0,0 +1,73 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stats
+
+import "sync"
+
+// Histogram buckets float64-millisecond latency samples the same way a
+// Prometheus histogram does: counts are cumulative, so counts[i] is the
+// number of samples <= Buckets[i], and the trailing, always-present
+// overflow bucket (counts[len(counts)-1]) is the +Inf bucket, equal to the
+// total sample count. Boundaries are expected in ascending order; Buckets
+// themselves are never mutated after NewHistogram, so reading them
+// concurrently with Observe is safe without locking.
+type Histogram struct {
+	Buckets []float64
+
+	mu     sync.Mutex
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+// NewHistogram builds a Histogram bucketing into buckets, or DefaultBuckets
+// if buckets is empty.
+func NewHistogram(buckets []float64) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	return &Histogram{
+		Buckets: buckets,
+		counts:  make([]int64, len(buckets)+1),
+	}
+}
+
+// Observe records a single latency sample, in milliseconds.
+func (h *Histogram) Observe(ms float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.sum += ms
+	for i, b := range h.Buckets {
+		if ms <= b {
+			h.counts[i]++
+		}
+	}
+	// The overflow bucket is the +Inf bucket: cumulative, so every sample
+	// lands in it regardless of which (if any) finite boundary it also hit.
+	h.counts[len(h.counts)-1]++
+}
+
+// Snapshot returns the cumulative per-bucket counts (len(Buckets)+1, the
+// last being the overflow bucket), the total sample count, and the sum of
+// every observed value, in the shape a Prometheus-style exporter expects.
+func (h *Histogram) Snapshot() (counts []int64, count int64, sum float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]int64(nil), h.counts...), h.count, h.sum
+}
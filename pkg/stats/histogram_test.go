@@ -0,0 +1,82 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stats
+
+import "testing"
+
+func TestHistogram_ObserveSubMillisecond(t *testing.T) {
+	h := NewHistogram([]float64{0.1, 0.25, 0.5, 1})
+
+	h.Observe(0.2)
+
+	counts, count, sum := h.Snapshot()
+	if count != 1 {
+		t.Fatalf("expected count 1, got %d", count)
+	}
+	if sum != 0.2 {
+		t.Fatalf("expected sum 0.2, got %v", sum)
+	}
+	// 0.2ms falls in the 0.25 bucket (index 1), not the 0ms/1ms bucket a
+	// plain integer-millisecond histogram would have collapsed it into.
+	if counts[1] != 1 {
+		t.Fatalf("expected the 0.25ms bucket to get the sample, got counts=%v", counts)
+	}
+	if counts[0] != 0 {
+		t.Fatalf("expected the 0.1ms bucket to stay empty, got counts=%v", counts)
+	}
+}
+
+func TestHistogram_CountsAreCumulative(t *testing.T) {
+	h := NewHistogram([]float64{1, 2, 3})
+
+	h.Observe(0.5)
+
+	counts, count, _ := h.Snapshot()
+	if count != 1 {
+		t.Fatalf("expected count 1, got %d", count)
+	}
+	// A sample <= the first boundary must also count toward every larger
+	// boundary (and the +Inf overflow bucket), the way Prometheus's
+	// cumulative "le" buckets do.
+	want := []int64{1, 1, 1, 1}
+	for i, w := range want {
+		if counts[i] != w {
+			t.Fatalf("expected cumulative counts %v, got %v", want, counts)
+		}
+	}
+}
+
+func TestHistogram_Overflow(t *testing.T) {
+	h := NewHistogram([]float64{1, 2})
+
+	h.Observe(100)
+
+	counts, count, _ := h.Snapshot()
+	if count != 1 {
+		t.Fatalf("expected count 1, got %d", count)
+	}
+	if counts[len(counts)-1] != 1 {
+		t.Fatalf("expected the overflow bucket to get the sample, got counts=%v", counts)
+	}
+}
+
+func TestHistogram_DefaultsWhenEmpty(t *testing.T) {
+	h := NewHistogram(nil)
+	if len(h.Buckets) != len(DefaultBuckets) {
+		t.Fatalf("expected DefaultBuckets to be used, got %v", h.Buckets)
+	}
+}
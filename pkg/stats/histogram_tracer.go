@@ -0,0 +1,53 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+type histogramStartKey struct{}
+
+// histogramTracer is kitex's built-in latency tracer: it times each RPC
+// end-to-end as a float64 number of milliseconds, so calls under 1ms still
+// land in a meaningful bucket instead of all collapsing into "0ms", and
+// records it into Hist.
+type histogramTracer struct {
+	Hist *Histogram
+}
+
+// NewHistogramTracer builds a Tracer that records each RPC's latency into
+// hist. Used by WithMetricsBucket to give the configured buckets an actual
+// consumer.
+func NewHistogramTracer(hist *Histogram) Tracer {
+	return &histogramTracer{Hist: hist}
+}
+
+// Start implements Tracer.
+func (t *histogramTracer) Start(ctx context.Context) context.Context {
+	return context.WithValue(ctx, histogramStartKey{}, time.Now())
+}
+
+// Finish implements Tracer.
+func (t *histogramTracer) Finish(ctx context.Context) {
+	start, ok := ctx.Value(histogramStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+	t.Hist.Observe(float64(time.Since(start)) / float64(time.Millisecond))
+}
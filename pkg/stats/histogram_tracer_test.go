@@ -0,0 +1,52 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHistogramTracer_RecordsLatency(t *testing.T) {
+	hist := NewHistogram([]float64{0.1, 1, 10, 100})
+	tracer := NewHistogramTracer(hist)
+
+	ctx := tracer.Start(context.Background())
+	time.Sleep(2 * time.Millisecond)
+	tracer.Finish(ctx)
+
+	_, count, sum := hist.Snapshot()
+	if count != 1 {
+		t.Fatalf("expected exactly 1 observation, got %d", count)
+	}
+	if sum <= 0 {
+		t.Fatalf("expected a positive recorded duration, got %v", sum)
+	}
+}
+
+func TestHistogramTracer_FinishWithoutStartIsNoop(t *testing.T) {
+	hist := NewHistogram(nil)
+	tracer := NewHistogramTracer(hist)
+
+	tracer.Finish(context.Background())
+
+	_, count, _ := hist.Snapshot()
+	if count != 0 {
+		t.Fatalf("expected no observation without a matching Start, got %d", count)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otel
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestMetadataCarrier_SetGet(t *testing.T) {
+	c := metadataCarrier{}
+	c.Set("traceparent", "00-abc-def-01")
+
+	if got := c.Get("traceparent"); got != "00-abc-def-01" {
+		t.Fatalf("expected to read back what was set, got %q", got)
+	}
+	if got := c.Get("missing"); got != "" {
+		t.Fatalf("expected empty string for an unset key, got %q", got)
+	}
+}
+
+func TestMetadataCarrier_Keys(t *testing.T) {
+	c := metadataCarrier{"traceparent": "a", "tracestate": "b"}
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	want := []string{"tracestate", "traceparent"}
+	sort.Strings(want)
+
+	if len(keys) != len(want) {
+		t.Fatalf("expected %v, got %v", want, keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, keys)
+		}
+	}
+}
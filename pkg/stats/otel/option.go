@@ -0,0 +1,77 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otel provides a stats.Tracer that reports RPCs as OpenTelemetry
+// client spans and propagates W3C trace context to the callee, so traces
+// stay continuous across Kitex clients and whatever sits behind them
+// (Envoy, Istio, another Kitex service, ...).
+package otel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// Option customizes the tracer built by NewClientTracer.
+type Option struct {
+	apply func(*options)
+}
+
+type options struct {
+	propagator   propagation.TextMapPropagator
+	attrsFunc    func(rpcinfo.RPCInfo) []attribute.KeyValue
+	samplingRate float64
+}
+
+// WithPropagator overrides the propagator used to inject trace context into
+// the outgoing call. Defaults to W3C tracecontext + baggage; pass a
+// composite propagator that also includes b3.New() to additionally emit B3
+// headers for callees that haven't migrated off them yet.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return Option{apply: func(o *options) { o.propagator = p }}
+}
+
+// WithSpanAttributes attaches extra attributes to the client span, derived
+// from the RPCInfo of the call it's tracing (e.g. the callee's idc/cluster
+// tag, or a request ID pulled from the context).
+func WithSpanAttributes(f func(rpcinfo.RPCInfo) []attribute.KeyValue) Option {
+	return Option{apply: func(o *options) { o.attrsFunc = f }}
+}
+
+// WithSamplingRate sets the fraction (0, 1] of RPCs that get a client span
+// and propagated trace context, decided independently of whatever sampler
+// the TracerProvider itself is configured with. Use this to match an
+// Envoy sidecar's client sampling percentage without round-tripping through
+// the SDK's sampler config. A rate <= 0 (the default) samples every call and
+// leaves sampling entirely up to the TracerProvider.
+func WithSamplingRate(rate float64) Option {
+	return Option{apply: func(o *options) { o.samplingRate = rate }}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
+	}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return o
+}
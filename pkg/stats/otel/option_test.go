@@ -0,0 +1,38 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otel
+
+import "testing"
+
+func TestNewOptions_Defaults(t *testing.T) {
+	o := newOptions(nil)
+
+	if o.propagator == nil {
+		t.Fatal("expected a default composite propagator, got nil")
+	}
+	if o.samplingRate != 0 {
+		t.Fatalf("expected default samplingRate 0 (sample everything), got %v", o.samplingRate)
+	}
+}
+
+func TestWithSamplingRate(t *testing.T) {
+	o := newOptions([]Option{WithSamplingRate(0.5)})
+
+	if o.samplingRate != 0.5 {
+		t.Fatalf("expected samplingRate 0.5, got %v", o.samplingRate)
+	}
+}
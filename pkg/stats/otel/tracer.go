@@ -0,0 +1,92 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otel
+
+import (
+	"context"
+	"math/rand"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/stats"
+)
+
+const tracerName = "github.com/cloudwego/kitex/pkg/stats/otel"
+
+// clientTracer is a stats.Tracer that starts a client span at RPC start and
+// propagates it to the callee via persistent metainfo values, so whichever
+// codec the call ends up using (TTHeader, gRPC metadata, ...) carries the
+// W3C trace context without any codec-specific code here.
+type clientTracer struct {
+	tracer trace.Tracer
+	opts   *options
+}
+
+// NewClientTracer builds a stats.Tracer that reports RPCs through tp.
+func NewClientTracer(tp trace.TracerProvider, opts ...Option) stats.Tracer {
+	return &clientTracer{
+		tracer: tp.Tracer(tracerName),
+		opts:   newOptions(opts),
+	}
+}
+
+// Start implements stats.Tracer.
+func (t *clientTracer) Start(ctx context.Context) context.Context {
+	if t.opts.samplingRate > 0 && rand.Float64() >= t.opts.samplingRate {
+		return ctx
+	}
+
+	ri := rpcinfo.GetRPCInfo(ctx)
+	if ri == nil {
+		return ctx
+	}
+	spanName := ri.To().ServiceName() + "/" + ri.To().Method()
+
+	ctx, span := t.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient))
+	if t.opts.attrsFunc != nil {
+		span.SetAttributes(t.opts.attrsFunc(ri)...)
+	}
+
+	carrier := metadataCarrier{}
+	t.opts.propagator.Inject(ctx, carrier)
+	for k, v := range carrier {
+		ctx = metainfo.WithPersistentValue(ctx, k, v)
+	}
+
+	return ctx
+}
+
+// Finish implements stats.Tracer.
+func (t *clientTracer) Finish(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	ri := rpcinfo.GetRPCInfo(ctx)
+	if ri == nil || ri.Stats() == nil {
+		return
+	}
+	if err := ri.Stats().Error(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestClientTracer_StartWithoutRPCInfoIsSafe pins down the bug where Start
+// dereferenced rpcinfo.GetRPCInfo's result without the nil check Finish
+// already had, panicking when called with no RPCInfo in context.
+func TestClientTracer_StartWithoutRPCInfoIsSafe(t *testing.T) {
+	tracer := NewClientTracer(trace.NewNoopTracerProvider())
+
+	ctx := context.Background()
+	got := tracer.Start(ctx)
+	if got != ctx {
+		t.Fatalf("expected Start to return the input context unchanged when there's no RPCInfo")
+	}
+}
+
+// TestClientTracer_FinishWithoutRPCInfoIsSafe exercises the existing guard
+// in Finish for the same missing-RPCInfo case.
+func TestClientTracer_FinishWithoutRPCInfoIsSafe(t *testing.T) {
+	tracer := NewClientTracer(trace.NewNoopTracerProvider())
+
+	tracer.Finish(context.Background())
+}
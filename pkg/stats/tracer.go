@@ -0,0 +1,43 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stats defines kitex's tracing/metrics extension points.
+package stats
+
+import "context"
+
+// Tracer is used to trace the rpc call duration event by event, including
+// netpoll event and rpc event.
+type Tracer interface {
+	Start(ctx context.Context) context.Context
+	Finish(ctx context.Context)
+}
+
+// Level controls the sampling rate of RPCStats fields.
+type Level int
+
+// stats levels
+const (
+	LevelDisabled Level = iota
+	LevelBase
+	LevelDetailed
+)
+
+// DefaultBuckets are the histogram boundaries (in milliseconds) NewHistogram
+// uses when it is given no buckets, e.g. WithMetricsBucket(nil). They include
+// sub-millisecond boundaries so fast in-datacenter calls don't all collapse
+// into a single "0ms" bucket.
+var DefaultBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2021 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package transport defines the transport protocol used between kitex client and server.
+package transport
+
+// Protocol is the transport protocol for kitex, use as bit mask.
+type Protocol int
+
+// transport protocol
+const PurePayload Protocol = 0
+
+const (
+	TTHeader Protocol = 1 << iota
+	Framed
+	HTTP
+	GRPC
+	QUIC
+)
+
+const (
+	TTHeaderFramed = TTHeader | Framed
+
+	Unknown = "unknown"
+)
+
+// String prints human readable information.
+func (p Protocol) String() string {
+	switch p {
+	case PurePayload:
+		return "PurePayload"
+	case TTHeader:
+		return "TTHeader"
+	case Framed:
+		return "Framed"
+	case TTHeaderFramed:
+		return "TTHeaderFramed"
+	case HTTP:
+		return "HTTP"
+	case GRPC:
+		return "GRPC"
+	case QUIC:
+		return "QUIC"
+	}
+	return Unknown
+}